@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// contextKey is the Gin context key the authenticated Claims are stored
+// under.
+const contextKey = "auth_claims"
+
+// Claims is the set of principal attributes carried by a bearer token.
+type Claims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role"`
+}
+
+// FromContext returns the Claims attached by Middleware, or false if the
+// request was not authenticated.
+func FromContext(c *gin.Context) (Claims, bool) {
+	v, ok := c.Get(contextKey)
+	if !ok {
+		return Claims{}, false
+	}
+	claims, ok := v.(Claims)
+	return claims, ok
+}
@@ -0,0 +1,28 @@
+// Package auth provides bearer-token authentication and role-based
+// authorization middleware for the HTTP API.
+package auth
+
+// Mode selects how a request's principal is established.
+type Mode string
+
+const (
+	// ModeJWT validates a JWT bearer token against Secret or a JWKS URL.
+	ModeJWT Mode = "jwt"
+	// ModeReverseProxy trusts a header set by a front door (e.g. Leviathan
+	// Agent) instead of validating a token itself.
+	ModeReverseProxy Mode = "reverse-proxy"
+)
+
+// Config mirrors lev.yaml's `auth` section.
+type Config struct {
+	Mode Mode `yaml:"mode"`
+
+	// JWT mode.
+	Secret  string `yaml:"secret"`   // HS256 shared secret
+	JWKSURL string `yaml:"jwks_url"` // RS256 key set, mutually exclusive with Secret
+
+	// ModeReverseProxy: header carrying the authenticated user, analogous to
+	// Leviathan Agent's ReverseProxyAuthUser convention.
+	ReverseProxyAuthUser string `yaml:"reverse_proxy_auth_user"`
+	ReverseProxyAuthRole string `yaml:"reverse_proxy_auth_role"`
+}
@@ -0,0 +1,10 @@
+package auth
+
+import "errors"
+
+var errMissingToken = errors.New("missing bearer token")
+
+// errNoSigningKey is returned by Middleware when JWT mode is configured
+// without a secret or JWKS URL, rather than silently verifying tokens
+// against an empty-string secret.
+var errNoSigningKey = errors.New("auth: JWT mode requires a secret or jwks_url")
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/MicahParks/keyfunc/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Middleware authenticates requests per cfg, attaching Claims to the Gin
+// context on success. It rejects missing, malformed, expired, or invalid
+// tokens with 401 before any handler runs.
+func Middleware(cfg Config) (gin.HandlerFunc, error) {
+	if cfg.Mode == ModeReverseProxy {
+		return reverseProxyMiddleware(cfg), nil
+	}
+
+	keyFunc, err := buildKeyFunc(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		tokenString, err := bearerToken(c.GetHeader("Authorization"))
+		if err != nil {
+			// EventSource clients can't set headers, so SSE endpoints pass
+			// the token as a query param instead.
+			tokenString = c.Query("token")
+		}
+		if tokenString == "" {
+			unauthorized(c, "missing bearer token")
+			return
+		}
+
+		var claims Claims
+		token, err := jwt.ParseWithClaims(tokenString, &claims, keyFunc)
+		if err != nil || !token.Valid {
+			unauthorized(c, "invalid or expired token")
+			return
+		}
+
+		c.Set(contextKey, claims)
+		c.Next()
+	}, nil
+}
+
+// reverseProxyMiddleware trusts cfg.ReverseProxyAuthUser/Role headers set by
+// a front door that has already authenticated the request, matching
+// Leviathan Agent's ReverseProxyAuthUser convention.
+func reverseProxyMiddleware(cfg Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.GetHeader(cfg.ReverseProxyAuthUser)
+		if user == "" {
+			unauthorized(c, "missing reverse proxy auth header")
+			return
+		}
+
+		c.Set(contextKey, Claims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: user},
+			Role:             c.GetHeader(cfg.ReverseProxyAuthRole),
+		})
+		c.Next()
+	}
+}
+
+func buildKeyFunc(cfg Config) (jwt.Keyfunc, error) {
+	if cfg.JWKSURL != "" {
+		jwks, err := keyfunc.Get(cfg.JWKSURL, keyfunc.Options{})
+		if err != nil {
+			return nil, err
+		}
+		return jwks.Keyfunc, nil
+	}
+
+	if cfg.Secret == "" {
+		return nil, errNoSigningKey
+	}
+
+	secret := []byte(cfg.Secret)
+	return func(token *jwt.Token) (interface{}, error) {
+		return secret, nil
+	}, nil
+}
+
+func bearerToken(header string) (string, error) {
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return "", errMissingToken
+	}
+	token := strings.TrimPrefix(header, prefix)
+	if token == "" {
+		return "", errMissingToken
+	}
+	return token, nil
+}
+
+func unauthorized(c *gin.Context, message string) {
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": message})
+}
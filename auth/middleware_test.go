@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter(t *testing.T, secret string) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	mw, err := Middleware(Config{Mode: ModeJWT, Secret: secret})
+	if err != nil {
+		t.Fatalf("Middleware() error = %v", err)
+	}
+
+	r := gin.New()
+	r.Use(mw)
+	r.GET("/protected", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func doRequest(r *gin.Engine, authHeader string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware_MissingToken(t *testing.T) {
+	r := newTestRouter(t, "test-secret")
+
+	w := doRequest(r, "")
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestMiddleware_InvalidToken(t *testing.T) {
+	r := newTestRouter(t, "test-secret")
+
+	w := doRequest(r, "Bearer not-a-valid-jwt")
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestMiddleware_ExpiredToken(t *testing.T) {
+	r := newTestRouter(t, "test-secret")
+
+	token, err := IssueToken("test-secret", "alice", "User", -time.Minute)
+	if err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+
+	w := doRequest(r, "Bearer "+token)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestMiddleware_ValidToken(t *testing.T) {
+	r := newTestRouter(t, "test-secret")
+
+	token, err := IssueToken("test-secret", "alice", "Admin", time.Minute)
+	if err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+
+	w := doRequest(r, "Bearer "+token)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,54 @@
+// Package cache provides a small Ristretto-backed read cache for the
+// /users list, with Prometheus hit/miss counters wired in.
+package cache
+
+import (
+	"github.com/dgraph-io/ristretto"
+
+	"test-backend/metrics"
+	"test-backend/store"
+)
+
+const usersListKey = "users:list"
+
+// UsersCache caches the full users list to avoid hitting the store on every
+// read. It is invalidated on any write (create/update/delete).
+type UsersCache struct {
+	cache *ristretto.Cache
+}
+
+// NewUsersCache builds a Ristretto cache sized for the small, read-heavy
+// /users list workload.
+func NewUsersCache() (*UsersCache, error) {
+	c, err := ristretto.NewCache(&ristretto.Config{
+		NumCounters: 1e4,
+		MaxCost:     1 << 20,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &UsersCache{cache: c}, nil
+}
+
+// Get returns the cached users list, recording a hit or miss.
+func (uc *UsersCache) Get() ([]store.User, bool) {
+	v, ok := uc.cache.Get(usersListKey)
+	if !ok {
+		metrics.CacheMissesTotal.WithLabelValues("users").Inc()
+		return nil, false
+	}
+	metrics.CacheHitsTotal.WithLabelValues("users").Inc()
+	return v.([]store.User), true
+}
+
+// Set stores the users list in the cache.
+func (uc *UsersCache) Set(users []store.User) {
+	uc.cache.Set(usersListKey, users, 1)
+	uc.cache.Wait()
+}
+
+// Invalidate drops the cached users list; call after any write.
+func (uc *UsersCache) Invalidate() {
+	uc.cache.Del(usersListKey)
+}
@@ -0,0 +1,13 @@
+// Package events implements a small pub/sub hub for streaming user
+// create/update/delete notifications to Server-Sent Events clients.
+package events
+
+import "time"
+
+// Event is a single user mutation notification.
+type Event struct {
+	ID        uint64    `json:"id"`
+	Type      string    `json:"type"` // user.created, user.updated, user.deleted
+	Data      []byte    `json:"data"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,87 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// subscriberBuffer bounds how many unsent events a slow client can
+// accumulate before it is dropped.
+const subscriberBuffer = 32
+
+// Hub fans out Events to subscribed SSE clients and keeps a ring buffer of
+// recent events so a briefly disconnected client can catch up via
+// Last-Event-ID instead of missing what happened while it was away.
+type Hub struct {
+	mu          sync.Mutex
+	nextID      uint64
+	ring        []Event
+	ringSize    int
+	subscribers map[uint64]chan Event
+	nextSubID   uint64
+}
+
+// NewHub builds a Hub retaining up to ringSize recent events for replay.
+func NewHub(ringSize int) *Hub {
+	return &Hub{
+		ringSize:    ringSize,
+		subscribers: make(map[uint64]chan Event),
+	}
+}
+
+// Publish assigns the next event ID, records the event in the ring buffer,
+// and delivers it to every subscriber. A subscriber whose buffer is full is
+// dropped rather than allowed to block publishers.
+func (h *Hub) Publish(eventType string, data []byte) Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.nextID++
+	event := Event{ID: h.nextID, Type: eventType, Data: data, CreatedAt: time.Now()}
+
+	h.ring = append(h.ring, event)
+	if len(h.ring) > h.ringSize {
+		h.ring = h.ring[len(h.ring)-h.ringSize:]
+	}
+
+	for id, ch := range h.subscribers {
+		select {
+		case ch <- event:
+		default:
+			close(ch)
+			delete(h.subscribers, id)
+		}
+	}
+
+	return event
+}
+
+// Subscribe registers a new client and returns its channel of future events
+// plus any buffered events newer than lastEventID for it to replay first.
+// Callers must call the returned unsubscribe func when the client
+// disconnects.
+func (h *Hub) Subscribe(lastEventID uint64) (ch <-chan Event, catchUp []Event, unsubscribe func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := h.nextSubID
+	h.nextSubID++
+
+	sub := make(chan Event, subscriberBuffer)
+	h.subscribers[id] = sub
+
+	for _, e := range h.ring {
+		if e.ID > lastEventID {
+			catchUp = append(catchUp, e)
+		}
+	}
+
+	return sub, catchUp, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if existing, ok := h.subscribers[id]; ok {
+			close(existing)
+			delete(h.subscribers, id)
+		}
+	}
+}
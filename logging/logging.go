@@ -0,0 +1,68 @@
+// Package logging configures the service's zap logger from lev.yaml and
+// provides request-scoped loggers carrying a correlation ID, so log lines
+// from this service can be joined with others in Leviathan's aggregation.
+package logging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Config selects the logger's verbosity, encoding, and sampling, mirroring
+// lev.yaml's `logging` section.
+type Config struct {
+	Level    string `yaml:"level"`    // debug, info, warn, error
+	Encoding string `yaml:"encoding"` // json or console
+	Sample   bool   `yaml:"sample"`
+}
+
+// New builds a zap.Logger from cfg, defaulting to info-level JSON logging
+// when cfg is empty so the service behaves sensibly with no configuration.
+func New(cfg Config) (*zap.Logger, error) {
+	level := zapcore.InfoLevel
+	if cfg.Level != "" {
+		if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+			return nil, err
+		}
+	}
+
+	encoding := cfg.Encoding
+	if encoding == "" {
+		encoding = "json"
+	}
+
+	zcfg := zap.Config{
+		Level:            zap.NewAtomicLevelAt(level),
+		Development:      false,
+		Encoding:         encoding,
+		EncoderConfig:    zap.NewProductionEncoderConfig(),
+		OutputPaths:      []string{"stdout"},
+		ErrorOutputPaths: []string{"stderr"},
+	}
+	zcfg.EncoderConfig.TimeKey = "timestamp"
+	zcfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	if cfg.Sample {
+		zcfg.Sampling = &zap.SamplingConfig{Initial: 100, Thereafter: 100}
+	}
+
+	return zcfg.Build()
+}
+
+type contextKey struct{}
+
+// WithContext returns a context carrying logger, retrievable via FromContext.
+func WithContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger attached to ctx, or zap.L() if none was
+// attached.
+func FromContext(ctx context.Context) *zap.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return zap.L()
+}
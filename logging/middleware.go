@@ -0,0 +1,41 @@
+package logging
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RequestIDHeader is the header used to propagate the correlation ID to and
+// from clients and downstream services.
+const RequestIDHeader = "X-Request-ID"
+
+// Middleware generates/propagates a request ID, attaches a request-scoped
+// logger to the request context, and logs a structured access entry once
+// the handler completes.
+func Middleware(base *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		c.Writer.Header().Set(RequestIDHeader, requestID)
+
+		reqLogger := base.With(zap.String("request_id", requestID))
+		c.Request = c.Request.WithContext(WithContext(c.Request.Context(), reqLogger))
+
+		start := time.Now()
+		c.Next()
+
+		reqLogger.Info("request",
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+			zap.Int("bytes", c.Writer.Size()),
+			zap.String("client_ip", c.ClientIP()),
+		)
+	}
+}
@@ -3,63 +3,146 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
 	leviathan "leviathan-bridge/lev-sdks"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+
+	"test-backend/auth"
+	"test-backend/cache"
+	"test-backend/events"
+	"test-backend/logging"
+	"test-backend/metrics"
+	"test-backend/queue"
+	"test-backend/store"
 )
 
-// Simple user model for testing
-type User struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
-	Role string `json:"role"`
-}
+// eventRingSize is how many recent /users mutation events the SSE hub keeps
+// for clients reconnecting with Last-Event-ID.
+const eventRingSize = 256
 
-// Mock data
-var users = []User{
-	{ID: 1, Name: "Alice", Role: "Admin"},
-	{ID: 2, Name: "Bob", Role: "User"},
-	{ID: 3, Name: "Charlie", Role: "Manager"},
-}
+// Simple user model for testing
+type User = store.User
 
 func main() {
-	log.Println("Starting Test Backend...")
-
-	// Initialize Leviathan SDK (will load lev.yaml automatically)
+	// Initialize Leviathan SDK first so lev.yaml is loaded before we build
+	// the logger from it. Neither step has a logger yet, so failures here
+	// still go through the stdlib logger rather than zap.
 	sdk, err := leviathan.NewBackendSDK("")
 	if err != nil {
 		log.Fatalf("Failed to initialize Leviathan SDK: %v", err)
 	}
 
+	config := sdk.GetConfig()
+	logCfg := logging.Config{}
+	if config != nil {
+		logCfg.Level = config.Logging.Level
+		logCfg.Encoding = config.Logging.Encoding
+		logCfg.Sample = config.Logging.Sample
+	}
+
+	logger, err := logging.New(logCfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+	zap.ReplaceGlobals(logger)
+
+	logger.Info("Starting Test Backend...")
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	// Auto-register with Leviathan Agent
 	if err := sdk.AutoRegister(ctx); err != nil {
-		log.Printf("Warning: Failed to register with Leviathan Agent: %v", err)
-		log.Println("Continuing without Leviathan registration...")
+		logger.Warn("Failed to register with Leviathan Agent", zap.Error(err))
+		logger.Info("Continuing without Leviathan registration...")
 	} else {
-		log.Println("✅ Successfully registered with Leviathan Agent")
+		logger.Info("Successfully registered with Leviathan Agent")
 		// Start heartbeat
 		if err := sdk.StartAutoHeartbeat(ctx, time.Minute); err != nil {
-			log.Printf("Warning: Failed to start heartbeat: %v", err)
+			logger.Warn("Failed to start heartbeat", zap.Error(err))
 		} else {
-			log.Println("✅ Heartbeat started")
+			logger.Info("Heartbeat started")
 		}
 	}
 
+	port := 8080
+	dbDriver, dbDSN := "", ""
+	authCfg := auth.Config{}
+	queueBackend, queueRedisAddr := "", ""
+	if config != nil {
+		port = config.Port
+		dbDriver = config.Database.Driver
+		dbDSN = config.Database.DSN
+		authCfg.Mode = auth.Mode(config.Auth.Mode)
+		authCfg.Secret = config.Auth.Secret
+		authCfg.JWKSURL = config.Auth.JWKSURL
+		authCfg.ReverseProxyAuthUser = config.Auth.ReverseProxyAuthUser
+		authCfg.ReverseProxyAuthRole = config.Auth.ReverseProxyAuthRole
+		queueBackend = config.Queue.Backend
+		queueRedisAddr = config.Queue.RedisAddr
+	}
+
+	userStore, err := store.New(store.LoadConfig(dbDriver, dbDSN))
+	if err != nil {
+		logger.Fatal("Failed to initialize store", zap.Error(err))
+	}
+
+	usersCache, err := cache.NewUsersCache()
+	if err != nil {
+		logger.Fatal("Failed to initialize users cache", zap.Error(err))
+	}
+
+	authMiddleware, err := auth.Middleware(authCfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize auth middleware", zap.Error(err))
+	}
+
+	qCfg := queue.LoadConfig(queueBackend, queueRedisAddr)
+	jobQueue, err := queue.New(qCfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize job queue", zap.Error(err))
+	}
+	bus := queue.NewEventBus()
+	registerJobHandlers(bus, logger)
+	workerPool := queue.NewPool(jobQueue, bus, qCfg.Workers)
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	workersDone := make(chan struct{})
+	go func() {
+		workerPool.Run(workersCtx)
+		close(workersDone)
+	}()
+
+	// ListenAndServe returns as soon as Shutdown starts, so main waits here
+	// until the signal handler below has finished draining.
+	shutdownDone := make(chan struct{})
+	defer func() { <-shutdownDone }()
+
+	eventHub := events.NewHub(eventRingSize)
+
+	h := &handlers{store: userStore, cache: usersCache, queue: jobQueue, pool: workerPool, hub: eventHub}
+
 	// Setup HTTP server
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
-	r.Use(gin.Logger(), gin.Recovery())
+	r.Use(gin.Recovery(), logging.Middleware(logger), metrics.Middleware())
 
 	// Health check endpoint (required by Leviathan Agent)
 	r.GET("/healthz", func(c *gin.Context) {
@@ -70,18 +153,21 @@ func main() {
 		})
 	})
 
-	// API endpoints
-	r.GET("/users", getUsers)
-	r.GET("/users/:id", getUser)
-	r.POST("/users", createUser)
-	r.GET("/info", getInfo)
+	// Prometheus scrape endpoint
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
-	// Get port from config
-	config := sdk.GetConfig()
-	port := 8080
-	if config != nil {
-		port = config.Port
-	}
+	// API endpoints. GET /users* requires any authenticated principal;
+	// POST /users additionally requires the Admin role.
+	users := r.Group("/users", authMiddleware)
+	users.GET("", h.getUsers)
+	users.GET("/:id", h.getUser)
+	users.POST("", auth.RequireRole("Admin"), h.createUser)
+	users.PUT("/:id", auth.RequireRole("Admin"), h.updateUser)
+	users.DELETE("/:id", auth.RequireRole("Admin"), h.deleteUser)
+	users.GET("/events", h.userEvents)
+
+	r.GET("/info", getInfo)
+	r.GET("/queue/stats", authMiddleware, auth.RequireRole("Admin"), h.queueStats)
 
 	// Start server
 	server := &http.Server{
@@ -95,70 +181,270 @@ func main() {
 		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 		<-sigChan
 
-		log.Println("Shutting down server...")
+		logger.Info("Shutting down server...")
 		cancel()
 
 		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer shutdownCancel()
 
 		if err := server.Shutdown(shutdownCtx); err != nil {
-			log.Printf("Server shutdown error: %v", err)
+			logger.Error("Server shutdown error", zap.Error(err))
+		}
+
+		// Let in-flight jobs finish before the process exits.
+		stopWorkers()
+		select {
+		case <-workersDone:
+			logger.Info("Job queue drained")
+		case <-time.After(10 * time.Second):
+			logger.Warn("Timed out waiting for job queue to drain")
 		}
-		log.Println("Server stopped")
+
+		logger.Info("Server stopped")
+		close(shutdownDone)
 	}()
 
-	log.Printf("Test Backend running on port %d", port)
-	log.Println("Available endpoints:")
-	log.Println("  GET  /healthz     - Health check")
-	log.Println("  GET  /users       - List all users")
-	log.Println("  GET  /users/:id   - Get user by ID")
-	log.Println("  POST /users       - Create new user")
-	log.Println("  GET  /info        - Service information")
+	logger.Info("Test Backend running", zap.Int("port", port))
+	logger.Info("Available endpoints",
+		zap.String("GET /healthz", "Health check"),
+		zap.String("GET /users", "List all users"),
+		zap.String("GET /users/:id", "Get user by ID"),
+		zap.String("POST /users", "Create new user"),
+		zap.String("PUT /users/:id", "Update user"),
+		zap.String("DELETE /users/:id", "Delete user"),
+		zap.String("GET /info", "Service information"),
+		zap.String("GET /metrics", "Prometheus metrics"),
+		zap.String("GET /queue/stats", "Job queue counters"),
+		zap.String("GET /users/events", "Stream user change events (SSE)"),
+	)
 
 	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		log.Fatalf("Server failed to start: %v", err)
+		logger.Fatal("Server failed to start", zap.Error(err))
 	}
 }
 
-// Handler functions
-func getUsers(c *gin.Context) {
+// handlers bundles the dependencies the HTTP handlers need, so the store can
+// be injected instead of read from a package-level variable.
+type handlers struct {
+	store store.UserStore
+	cache *cache.UsersCache
+	queue queue.Queue
+	pool  *queue.Pool
+	hub   *events.Hub
+}
+
+// storeErrStatus maps store errors to HTTP status codes.
+func storeErrStatus(err error) int {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, store.ErrConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *handlers) getUsers(c *gin.Context) {
+	if users, ok := h.cache.Get(); ok {
+		c.JSON(200, gin.H{
+			"users": users,
+			"count": len(users),
+		})
+		return
+	}
+
+	users, err := h.store.List(c.Request.Context())
+	if err != nil {
+		c.JSON(storeErrStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	h.cache.Set(users)
 	c.JSON(200, gin.H{
 		"users": users,
 		"count": len(users),
 	})
 }
 
-func getUser(c *gin.Context) {
-	id := c.Param("id")
-
-	for _, user := range users {
-		if fmt.Sprintf("%d", user.ID) == id {
-			c.JSON(200, user)
-			return
-		}
+func (h *handlers) getUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": "invalid user id"})
+		return
 	}
 
-	c.JSON(404, gin.H{"error": "User not found"})
+	user, err := h.store.Get(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(storeErrStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, user)
 }
 
-func createUser(c *gin.Context) {
+func (h *handlers) createUser(c *gin.Context) {
 	var newUser User
 	if err := c.ShouldBindJSON(&newUser); err != nil {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Generate new ID
-	maxID := 0
-	for _, user := range users {
-		if user.ID > maxID {
-			maxID = user.ID
+	created, err := h.store.Create(c.Request.Context(), newUser)
+	if err != nil {
+		c.JSON(storeErrStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	h.cache.Invalidate()
+
+	payload, _ := json.Marshal(created)
+	job := queue.Work{
+		ID:        uuid.NewString(),
+		Kind:      "user.created",
+		Payload:   payload,
+		CreatedAt: time.Now(),
+	}
+	if err := h.queue.Enqueue(c.Request.Context(), job); err != nil {
+		logging.FromContext(c.Request.Context()).Warn("Failed to enqueue user.created job", zap.Error(err))
+	}
+
+	h.hub.Publish("user.created", payload)
+
+	c.JSON(201, created)
+}
+
+// queueStats reports the job queue's depth, in-flight, and failed counters.
+func (h *handlers) queueStats(c *gin.Context) {
+	depth, err := h.pool.Depth(c.Request.Context())
+	if err != nil {
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	stats := h.pool.Stats()
+	c.JSON(200, gin.H{
+		"depth":    depth,
+		"inflight": stats.Inflight(),
+		"failed":   stats.Failed(),
+	})
+}
+
+func (h *handlers) updateUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	var update User
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.store.Update(c.Request.Context(), id, update)
+	if err != nil {
+		c.JSON(storeErrStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	h.cache.Invalidate()
+
+	payload, _ := json.Marshal(updated)
+	h.hub.Publish("user.updated", payload)
+
+	c.JSON(200, updated)
+}
+
+// userEvents streams user create/update/delete events over SSE. A client
+// reconnecting with Last-Event-ID (set automatically by EventSource) replays
+// events missed during the disconnect; a fresh client with no header gets
+// only events published from here on.
+func (h *handlers) userEvents(c *gin.Context) {
+	var lastEventID uint64
+	rawLastEventID := c.GetHeader("Last-Event-ID")
+	if rawLastEventID != "" {
+		lastEventID, _ = strconv.ParseUint(rawLastEventID, 10, 64)
+	}
+
+	ch, catchUp, unsubscribe := h.hub.Subscribe(lastEventID)
+	defer unsubscribe()
+	if rawLastEventID == "" {
+		catchUp = nil
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+
+	for _, e := range catchUp {
+		writeSSEEvent(c.Writer, e)
+	}
+	c.Writer.Flush()
+
+	clientGone := c.Request.Context().Done()
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				// The hub dropped us for falling too far behind. A 503
+				// can't be sent this late (the 200 and headers are already
+				// on the wire), so we tell the client explicitly with a
+				// terminal event instead of just closing the connection.
+				writeSSEDisconnect(c.Writer, "backpressure: client fell behind")
+				c.Writer.Flush()
+				return
+			}
+			writeSSEEvent(c.Writer, e)
+			c.Writer.Flush()
+		case <-clientGone:
+			return
 		}
 	}
-	newUser.ID = maxID + 1
+}
+
+func writeSSEEvent(w io.Writer, e events.Event) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data)
+}
 
-	users = append(users, newUser)
-	c.JSON(201, newUser)
+// writeSSEDisconnect emits a terminal "disconnect" event so EventSource
+// clients know to stop relying on this stream and reconnect, since an HTTP
+// status code is no longer available once the response is committed.
+func writeSSEDisconnect(w io.Writer, reason string) {
+	fmt.Fprintf(w, "event: disconnect\ndata: {\"reason\":%q}\n\n", reason)
+}
+
+func (h *handlers) deleteUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	if err := h.store.Delete(c.Request.Context(), id); err != nil {
+		c.JSON(storeErrStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	h.cache.Invalidate()
+
+	payload, _ := json.Marshal(gin.H{"id": id})
+	h.hub.Publish("user.deleted", payload)
+
+	c.Status(204)
+}
+
+// registerJobHandlers wires up the side effects that follow user creation.
+// New subscribers can be added here without touching the HTTP layer.
+func registerJobHandlers(bus *queue.EventBus, logger *zap.Logger) {
+	bus.Subscribe("user.created", func(ctx context.Context, w queue.Work) error {
+		logger.Info("Sending welcome email", zap.String("job_id", w.ID))
+		return nil
+	})
+	bus.Subscribe("user.created", func(ctx context.Context, w queue.Work) error {
+		logger.Info("Recording audit log entry", zap.String("job_id", w.ID))
+		return nil
+	})
+	bus.Subscribe("user.created", func(ctx context.Context, w queue.Work) error {
+		logger.Info("Notifying webhook subscribers", zap.String("job_id", w.ID))
+		return nil
+	})
 }
 
 func getInfo(c *gin.Context) {
@@ -166,6 +452,6 @@ func getInfo(c *gin.Context) {
 		"service":   "test-api",
 		"version":   "1.0.0",
 		"timestamp": time.Now().Unix(),
-		"uptime":    time.Since(time.Now()).String(),
+		"uptime":    metrics.Uptime().String(),
 	})
 }
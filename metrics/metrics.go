@@ -0,0 +1,50 @@
+// Package metrics records process and HTTP-level Prometheus metrics for the
+// service and tracks its start time for uptime reporting.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var startTime = time.Now()
+
+// Uptime returns how long the process has been running.
+func Uptime() time.Duration {
+	return time.Since(startTime)
+}
+
+var (
+	// RequestsTotal counts completed HTTP requests by route, method, and status.
+	RequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests processed.",
+	}, []string{"route", "method", "status"})
+
+	// RequestDuration tracks request latency by route and method.
+	RequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	// RequestsInFlight tracks requests currently being served.
+	RequestsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "Number of HTTP requests currently being served.",
+	})
+
+	// CacheHitsTotal and CacheMissesTotal count reads served from or missed by
+	// the users-list cache, labeled by cache name.
+	CacheHitsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_hits_total",
+		Help: "Total number of cache hits.",
+	}, []string{"cache"})
+
+	CacheMissesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_misses_total",
+		Help: "Total number of cache misses.",
+	}, []string{"cache"})
+)
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware returns a Gin middleware that records request counts and
+// latency per route and method, keyed by the matched route template (not the
+// raw path) so dynamic segments like :id don't explode the metric
+// cardinality. The in-flight gauge is a single total; it is not broken down
+// by route or method.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		RequestsInFlight.Inc()
+		defer RequestsInFlight.Dec()
+
+		start := time.Now()
+		c.Next()
+		elapsed := time.Since(start).Seconds()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		method := c.Request.Method
+		status := strconv.Itoa(c.Writer.Status())
+
+		RequestsTotal.WithLabelValues(route, method, status).Inc()
+		RequestDuration.WithLabelValues(route, method).Observe(elapsed)
+	}
+}
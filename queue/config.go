@@ -0,0 +1,49 @@
+package queue
+
+import "os"
+
+// Backend selects which Queue implementation to build.
+type Backend string
+
+const (
+	BackendMemory Backend = "memory"
+	BackendRedis  Backend = "redis"
+)
+
+// Config mirrors lev.yaml's `queue` section, overridable by env vars so it
+// can be tuned per-deployment without touching the config file.
+type Config struct {
+	Backend    Backend `yaml:"backend"`
+	RedisAddr  string  `yaml:"redis_addr"`
+	RedisKey   string  `yaml:"redis_key"`
+	BufferSize int     `yaml:"buffer_size"`
+	Workers    int     `yaml:"workers"`
+}
+
+// LoadConfig applies QUEUE_BACKEND/QUEUE_REDIS_ADDR env overrides and
+// defaults to an in-memory queue with a modest buffer and worker pool.
+func LoadConfig(backend, redisAddr string) Config {
+	cfg := Config{Backend: Backend(backend), RedisAddr: redisAddr}
+
+	if v := os.Getenv("QUEUE_BACKEND"); v != "" {
+		cfg.Backend = Backend(v)
+	}
+	if v := os.Getenv("QUEUE_REDIS_ADDR"); v != "" {
+		cfg.RedisAddr = v
+	}
+
+	if cfg.Backend == "" {
+		cfg.Backend = BackendMemory
+	}
+	if cfg.RedisKey == "" {
+		cfg.RedisKey = "test-backend:jobs"
+	}
+	if cfg.BufferSize == 0 {
+		cfg.BufferSize = 256
+	}
+	if cfg.Workers == 0 {
+		cfg.Workers = 4
+	}
+
+	return cfg
+}
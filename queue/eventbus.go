@@ -0,0 +1,41 @@
+package queue
+
+import (
+	"context"
+	"sync"
+)
+
+// Handler processes one Work item. An error causes the worker pool to retry
+// with backoff.
+type Handler func(ctx context.Context, w Work) error
+
+// EventBus lets side-effect handlers (welcome email, audit log, webhook) be
+// registered by Kind without the HTTP layer knowing they exist.
+type EventBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// NewEventBus builds an empty EventBus.
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers handler to run for every Work of the given kind.
+func (b *EventBus) Subscribe(kind string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[kind] = append(b.handlers[kind], handler)
+}
+
+// Handlers returns the handlers subscribed to kind. The worker pool retries
+// each one independently, so a failure in one handler never re-runs a
+// sibling that already succeeded.
+func (b *EventBus) Handlers(kind string) []Handler {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	handlers := make([]Handler, len(b.handlers[kind]))
+	copy(handlers, b.handlers[kind])
+	return handlers
+}
@@ -0,0 +1,43 @@
+package queue
+
+import (
+	"context"
+	"errors"
+)
+
+// memoryQueue is a channel-backed Queue for single-process deployments and
+// tests.
+type memoryQueue struct {
+	items chan Work
+}
+
+// NewMemoryQueue builds a buffered, channel-backed Queue holding up to size
+// items before Enqueue blocks.
+func NewMemoryQueue(size int) Queue {
+	return &memoryQueue{items: make(chan Work, size)}
+}
+
+func (q *memoryQueue) Enqueue(ctx context.Context, w Work) error {
+	select {
+	case q.items <- w:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *memoryQueue) Dequeue(ctx context.Context) (Work, error) {
+	select {
+	case w, ok := <-q.items:
+		if !ok {
+			return Work{}, errors.New("queue: closed")
+		}
+		return w, nil
+	case <-ctx.Done():
+		return Work{}, ctx.Err()
+	}
+}
+
+func (q *memoryQueue) Depth(ctx context.Context) (int, error) {
+	return len(q.items), nil
+}
@@ -0,0 +1,15 @@
+package queue
+
+import "fmt"
+
+// New builds the Queue selected by cfg.Backend.
+func New(cfg Config) (Queue, error) {
+	switch cfg.Backend {
+	case BackendMemory:
+		return NewMemoryQueue(cfg.BufferSize), nil
+	case BackendRedis:
+		return NewRedisQueue(cfg.RedisAddr, cfg.RedisKey), nil
+	default:
+		return nil, fmt.Errorf("queue: unsupported backend %q", cfg.Backend)
+	}
+}
@@ -0,0 +1,91 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// Pool is a fixed-size worker pool that pulls Work from a Queue and
+// dispatches it through an EventBus, retrying failed handlers with
+// exponential backoff before giving up and counting the job as failed.
+type Pool struct {
+	queue Queue
+	bus   *EventBus
+	stats Stats
+
+	workers int
+	wg      sync.WaitGroup
+}
+
+// NewPool builds a Pool with the given number of worker goroutines.
+func NewPool(q Queue, bus *EventBus, workers int) *Pool {
+	return &Pool{queue: q, bus: bus, workers: workers}
+}
+
+// Stats returns the pool's live counters.
+func (p *Pool) Stats() *Stats {
+	return &p.stats
+}
+
+// Depth reports the number of items currently waiting in the queue.
+func (p *Pool) Depth(ctx context.Context) (int, error) {
+	return p.queue.Depth(ctx)
+}
+
+// Run starts the worker goroutines. It blocks until ctx is cancelled, then
+// waits for in-flight work to finish before returning, so callers can drain
+// the pool gracefully on shutdown. Cancelling ctx only stops workers from
+// picking up new work; a job already being processed runs to completion on
+// its own context rather than being aborted mid-retry.
+func (p *Pool) Run(ctx context.Context) {
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go p.worker(ctx)
+	}
+	p.wg.Wait()
+}
+
+func (p *Pool) worker(ctx context.Context) {
+	defer p.wg.Done()
+
+	for {
+		w, err := p.queue.Dequeue(ctx)
+		if err != nil {
+			// ctx cancelled (shutdown), the queue closed, or (Redis) a
+			// connection or decode error; in every case this worker stops
+			// pulling new work. Anything already dequeued is handled by
+			// process() below, which runs on its own context.
+			return
+		}
+		p.process(context.Background(), w)
+	}
+}
+
+// process runs every handler subscribed to w.Kind, retrying each one
+// independently with its own backoff. Handlers aren't idempotent (welcome
+// email, audit log, webhook), so a handler that already succeeded must never
+// be re-run just because a later one is still retrying.
+func (p *Pool) process(ctx context.Context, w Work) {
+	p.stats.inflightAdd(1)
+	defer p.stats.inflightAdd(-1)
+
+	failed := false
+	for _, handler := range p.bus.Handlers(w.Kind) {
+		b := backoff.NewExponentialBackOff()
+		b.InitialInterval = 10 * time.Millisecond
+		b.MaxInterval = 10 * time.Second
+
+		if err := backoff.Retry(func() error {
+			return handler(ctx, w)
+		}, backoff.WithContext(b, ctx)); err != nil {
+			failed = true
+		}
+	}
+
+	if failed {
+		p.stats.failedAdd(1)
+	}
+}
@@ -0,0 +1,15 @@
+package queue
+
+import "context"
+
+// Queue is the contract the worker pool depends on. It is implemented by an
+// in-memory channel-backed queue and a Redis-backed queue, selectable by
+// config so the same worker pool code runs against either.
+type Queue interface {
+	// Enqueue publishes w for later processing.
+	Enqueue(ctx context.Context, w Work) error
+	// Dequeue blocks until work is available or ctx is cancelled.
+	Dequeue(ctx context.Context) (Work, error)
+	// Depth reports the number of items currently waiting to be processed.
+	Depth(ctx context.Context) (int, error)
+}
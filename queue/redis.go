@@ -0,0 +1,58 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisQueue is a Redis list-backed Queue, for multi-process deployments
+// that need the job queue to survive a process restart.
+type redisQueue struct {
+	client *redis.Client
+	key    string
+}
+
+// NewRedisQueue builds a Queue backed by the Redis list at key.
+func NewRedisQueue(addr, key string) Queue {
+	return &redisQueue{
+		client: redis.NewClient(&redis.Options{Addr: addr}),
+		key:    key,
+	}
+}
+
+func (q *redisQueue) Enqueue(ctx context.Context, w Work) error {
+	data, err := json.Marshal(w)
+	if err != nil {
+		return fmt.Errorf("queue: marshal work: %w", err)
+	}
+	if err := q.client.LPush(ctx, q.key, data).Err(); err != nil {
+		return fmt.Errorf("queue: lpush: %w", err)
+	}
+	return nil
+}
+
+func (q *redisQueue) Dequeue(ctx context.Context) (Work, error) {
+	// BRPop blocks server-side; 0 means wait indefinitely for ctx cancellation.
+	result, err := q.client.BRPop(ctx, 0, q.key).Result()
+	if err != nil {
+		return Work{}, fmt.Errorf("queue: brpop: %w", err)
+	}
+
+	var w Work
+	// result[0] is the key name, result[1] is the payload.
+	if err := json.Unmarshal([]byte(result[1]), &w); err != nil {
+		return Work{}, fmt.Errorf("queue: unmarshal work: %w", err)
+	}
+	return w, nil
+}
+
+func (q *redisQueue) Depth(ctx context.Context) (int, error) {
+	n, err := q.client.LLen(ctx, q.key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("queue: llen: %w", err)
+	}
+	return int(n), nil
+}
@@ -0,0 +1,27 @@
+package queue
+
+import "sync/atomic"
+
+// Stats holds the counters exposed at GET /queue/stats.
+type Stats struct {
+	inflight int64
+	failed   int64
+}
+
+// Inflight returns the number of jobs currently being processed.
+func (s *Stats) Inflight() int64 {
+	return atomic.LoadInt64(&s.inflight)
+}
+
+// Failed returns the number of jobs that exhausted retries.
+func (s *Stats) Failed() int64 {
+	return atomic.LoadInt64(&s.failed)
+}
+
+func (s *Stats) inflightAdd(delta int64) {
+	atomic.AddInt64(&s.inflight, delta)
+}
+
+func (s *Stats) failedAdd(delta int64) {
+	atomic.AddInt64(&s.failed, delta)
+}
@@ -0,0 +1,16 @@
+// Package queue provides an asynchronous job queue so HTTP handlers can
+// publish side-effect work (emails, audit logs, webhooks) without blocking
+// on it, backed by either an in-memory channel or Redis.
+package queue
+
+import "time"
+
+// Work is a single unit of queued work. Kind identifies which EventBus
+// subscribers should handle it; Payload is the JSON-encoded event data.
+type Work struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	Payload   []byte    `json:"payload"`
+	CreatedAt time.Time `json:"created_at"`
+	Attempt   int       `json:"attempt"`
+}
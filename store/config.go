@@ -0,0 +1,43 @@
+package store
+
+import "os"
+
+// Driver identifies which GORM dialector to open.
+type Driver string
+
+const (
+	DriverSQLite   Driver = "sqlite"
+	DriverPostgres Driver = "postgres"
+	DriverMySQL    Driver = "mysql"
+)
+
+// Config selects the backing database and how to connect to it. It is
+// populated from lev.yaml's `database` section, falling back to env vars so
+// it can be overridden per-deployment without touching the config file.
+type Config struct {
+	Driver Driver `yaml:"driver"`
+	DSN    string `yaml:"dsn"`
+}
+
+// LoadConfig builds a Config from the given lev.yaml values, applying env var
+// overrides (DB_DRIVER, DB_DSN) and a SQLite default so the service still
+// boots with no configuration at all.
+func LoadConfig(driver, dsn string) Config {
+	cfg := Config{Driver: Driver(driver), DSN: dsn}
+
+	if v := os.Getenv("DB_DRIVER"); v != "" {
+		cfg.Driver = Driver(v)
+	}
+	if v := os.Getenv("DB_DSN"); v != "" {
+		cfg.DSN = v
+	}
+
+	if cfg.Driver == "" {
+		cfg.Driver = DriverSQLite
+	}
+	if cfg.DSN == "" && cfg.Driver == DriverSQLite {
+		cfg.DSN = "test-backend.db"
+	}
+
+	return cfg
+}
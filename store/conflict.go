@@ -0,0 +1,25 @@
+package store
+
+import "strings"
+
+// isUniqueViolation reports whether err is a unique-constraint violation
+// from any of the supported drivers. GORM doesn't normalize these into a
+// common sentinel, and checking each driver's typed error would mean
+// importing mattn/go-sqlite3, lib/pq, and go-sql-driver/mysql's error
+// types directly, so we match on their well-known messages instead.
+func isUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "unique constraint"): // sqlite, postgres
+		return true
+	case strings.Contains(msg, "duplicate key value"): // postgres
+		return true
+	case strings.Contains(msg, "duplicate entry"): // mysql
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,114 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// gormUserStore is the GORM-backed UserStore implementation shared by every
+// supported driver; only the dialector used to open db differs.
+type gormUserStore struct {
+	db *gorm.DB
+}
+
+// New opens a database connection for cfg.Driver, runs schema migrations,
+// and returns a ready-to-use UserStore.
+func New(cfg Config) (UserStore, error) {
+	var dialector gorm.Dialector
+
+	switch cfg.Driver {
+	case DriverSQLite:
+		dialector = sqlite.Open(cfg.DSN)
+	case DriverPostgres:
+		dialector = postgres.Open(cfg.DSN)
+	case DriverMySQL:
+		dialector = mysql.Open(cfg.DSN)
+	default:
+		return nil, fmt.Errorf("store: unsupported driver %q", cfg.Driver)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("store: open %s: %w", cfg.Driver, err)
+	}
+
+	if err := db.AutoMigrate(&User{}); err != nil {
+		return nil, fmt.Errorf("store: migrate: %w", err)
+	}
+
+	return &gormUserStore{db: db}, nil
+}
+
+func (s *gormUserStore) List(ctx context.Context) ([]User, error) {
+	var users []User
+	if err := s.db.WithContext(ctx).Order("id").Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("store: list users: %w", err)
+	}
+	return users, nil
+}
+
+func (s *gormUserStore) Get(ctx context.Context, id int) (User, error) {
+	var u User
+	err := s.db.WithContext(ctx).First(&u, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return User{}, ErrNotFound
+	}
+	if err != nil {
+		return User{}, fmt.Errorf("store: get user %d: %w", id, err)
+	}
+	return u, nil
+}
+
+// Create inserts u, letting the database assign the ID via its auto-
+// increment/identity column so concurrent creates never race over a
+// manually computed next ID.
+func (s *gormUserStore) Create(ctx context.Context, u User) (User, error) {
+	u.ID = 0
+	if err := s.db.WithContext(ctx).Create(&u).Error; err != nil {
+		if isUniqueViolation(err) {
+			return User{}, ErrConflict
+		}
+		return User{}, fmt.Errorf("store: create user: %w", err)
+	}
+	return u, nil
+}
+
+// Update replaces the row at id with u. It writes a map rather than handing
+// GORM the struct directly, because struct-based Updates silently skips
+// zero-value fields — which would leave the old name/role in place instead
+// of honoring PUT's replace semantics when a caller clears one to "".
+func (s *gormUserStore) Update(ctx context.Context, id int, u User) (User, error) {
+	values := map[string]interface{}{
+		"name": u.Name,
+		"role": u.Role,
+	}
+
+	result := s.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(values)
+	if result.Error != nil {
+		if isUniqueViolation(result.Error) {
+			return User{}, ErrConflict
+		}
+		return User{}, fmt.Errorf("store: update user %d: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return User{}, ErrNotFound
+	}
+	return s.Get(ctx, id)
+}
+
+func (s *gormUserStore) Delete(ctx context.Context, id int) error {
+	result := s.db.WithContext(ctx).Delete(&User{}, "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("store: delete user %d: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+// Package store provides persistence for application models behind a small
+// interface so handlers never depend on a concrete database driver.
+package store
+
+import (
+	"context"
+	"errors"
+)
+
+// Sentinel errors returned by UserStore implementations. Callers map these
+// to HTTP status codes instead of inspecting driver-specific errors.
+var (
+	ErrNotFound = errors.New("store: user not found")
+	ErrConflict = errors.New("store: user already exists")
+)
+
+// User is the persisted representation of an application user.
+type User struct {
+	ID   int    `gorm:"primaryKey" json:"id"`
+	Name string `gorm:"size:255;not null" json:"name"`
+	Role string `gorm:"size:64;not null;default:User" json:"role"`
+}
+
+// TableName pins the table name so it doesn't change if the struct is renamed.
+func (User) TableName() string {
+	return "users"
+}
+
+// UserStore is the persistence contract the HTTP handlers depend on. It is
+// implemented by gormUserStore, backed by SQLite, Postgres, or MySQL.
+type UserStore interface {
+	List(ctx context.Context) ([]User, error)
+	Get(ctx context.Context, id int) (User, error)
+	Create(ctx context.Context, u User) (User, error)
+	Update(ctx context.Context, id int, u User) (User, error)
+	Delete(ctx context.Context, id int) error
+}